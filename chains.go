@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ChainSummary is the per-chain outcome of a multi-chain sweep, reported
+// alongside its sibling chains in the final JSON summary.
+type ChainSummary struct {
+	Name    string       `json:"name"`
+	RPC     string       `json:"rpc"`
+	Summary SweepSummary `json:"summary,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// sweepChains runs sweepChain for every configured chain, sweeping the same
+// set of accounts across each one in turn. A failure on one chain (e.g. a
+// dial error or a pre-flight mismatch) is recorded on its ChainSummary and
+// does not prevent the remaining chains from being swept.
+func sweepChains(config *Config, accounts []Account) []ChainSummary {
+	summaries := make([]ChainSummary, 0, len(config.Chains))
+	for _, chain := range config.Chains {
+		summaries = append(summaries, sweepChain(config, chain, accounts))
+	}
+	return summaries
+}
+
+// sweepChain dials chain's RPC endpoint, optionally pre-flights its chain ID,
+// and sweeps accounts to chain's receiver address.
+//
+// Note: SuggestGasPrice and SuggestGasTipCap are queried directly from
+// chain's RPC endpoint, so on L2s like Optimism and Arbitrum the reported
+// price already accounts for that chain's L1 data fee component; no
+// additional override is needed here.
+func sweepChain(config *Config, chain ChainConfig, accounts []Account) ChainSummary {
+	result := ChainSummary{Name: chain.Name, RPC: chain.RPC}
+
+	client, err := ethclient.Dial(chain.RPC)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to connect to the EVM client: %v", err)
+		return result
+	}
+
+	if chain.ExpectedChainID != 0 {
+		if err := chainPreflight(client, chain.ExpectedChainID); err != nil {
+			result.Error = fmt.Sprintf("chain pre-flight check failed: %v", err)
+			return result
+		}
+	}
+
+	receiverAddress := common.HexToAddress(chain.Receiver)
+	result.Summary = sweepAll(client, config, accounts, receiverAddress)
+	return result
+}
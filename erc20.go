@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// erc20ABI is the minimal standard ERC-20 interface needed to read a
+// holder's balance and transfer it, without pulling in a generated
+// contract binding.
+const erc20ABI = `[
+	{"constant":true,"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":false,"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"type":"function"}
+]`
+
+// sweepERC20Tokens transfers the sender's entire balance of each configured
+// token to receiverAddress, waiting for each transfer to be mined before
+// moving on to the next token, and returns the hashes of the transfers it
+// broadcast. Tokens with a zero balance are skipped. In -dry-run mode, only
+// the token balances are read; token transfers are neither priced, signed
+// nor sent, and no hashes are returned.
+//
+// Each token's balanceOf read is retried on its own (it is idempotent); once
+// a transfer has been broadcast it is not retried, so sweepERC20Tokens itself
+// should not be wrapped in an outer retry.
+func sweepERC20Tokens(client *ethclient.Client, config *Config, account Account, receiverAddress common.Address) ([]string, error) {
+	if len(config.Tokens) == 0 {
+		return nil, nil
+	}
+
+	parsedABI, err := abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ERC-20 ABI: %v", err)
+	}
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+	}
+
+	backoff := retryBackoff(config)
+
+	var txHashes []string
+	for _, tokenAddrHex := range config.Tokens {
+		if !common.IsHexAddress(tokenAddrHex) {
+			return txHashes, fmt.Errorf("invalid token address: %s", tokenAddrHex)
+		}
+		tokenAddress := common.HexToAddress(tokenAddrHex)
+		token := bind.NewBoundContract(tokenAddress, parsedABI, client, client, client)
+
+		var balanceOut []interface{}
+		err = withRetry(config.MaxRetries, backoff, func() error {
+			balanceOut, err = callERC20(token, "balanceOf", account.Address)
+			return err
+		})
+		if err != nil {
+			return txHashes, fmt.Errorf("failed to query token balance for %s: %v", tokenAddrHex, err)
+		}
+		balance := *abi.ConvertType(balanceOut[0], new(big.Int)).(*big.Int)
+		if balance.Sign() <= 0 {
+			continue
+		}
+
+		if config.DryRun {
+			continue
+		}
+
+		auth := &bind.TransactOpts{
+			From:    account.Address,
+			Context: context.Background(),
+			Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return account.SignTx(tx, chainID)
+			},
+		}
+
+		tx, err := token.Transact(auth, "transfer", receiverAddress, &balance)
+		if err != nil {
+			return txHashes, fmt.Errorf("failed to send token transfer for %s: %v", tokenAddrHex, err)
+		}
+		log.Printf("Token transfer sent for %s: %s", tokenAddrHex, tx.Hash().Hex())
+		txHashes = append(txHashes, tx.Hash().Hex())
+
+		receipt, err := bind.WaitMined(context.Background(), client, tx)
+		if err != nil {
+			return txHashes, fmt.Errorf("token transfer for %s not confirmed: %v", tokenAddrHex, err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return txHashes, fmt.Errorf("token transfer for %s reverted", tokenAddrHex)
+		}
+	}
+	return txHashes, nil
+}
+
+// callERC20 runs a read-only ERC-20 method call and returns its raw outputs.
+func callERC20(token *bind.BoundContract, method string, args ...interface{}) ([]interface{}, error) {
+	var out []interface{}
+	err := token.Call(&bind.CallOpts{Context: context.Background()}, &out, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/console/prompt"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Account is a signer-agnostic handle on a sweepable account: an address
+// plus a function that can sign a transaction on its behalf, regardless of
+// whether the underlying key material is a raw private key, a keystore
+// entry, a derived mnemonic key, or a remote clef signer.
+type Account struct {
+	Address common.Address
+	SignTx  func(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// KeySource discovers the accounts a sweep run should operate on.
+type KeySource interface {
+	Accounts() ([]Account, error)
+}
+
+// resolveKeySource picks the KeySource implied by config, preferring (in
+// order) an external signer, a keystore directory, a mnemonic file, and
+// finally the plaintext SenderPrivateKeys list.
+func resolveKeySource(config *Config) (KeySource, error) {
+	switch {
+	case config.ExternalSigner != "":
+		return externalSignerKeySource{endpoint: config.ExternalSigner}, nil
+	case config.KeystoreDir != "":
+		return keystoreKeySource{dir: config.KeystoreDir}, nil
+	case config.MnemonicFile != "":
+		return mnemonicKeySource{file: config.MnemonicFile, count: config.DerivationCount}, nil
+	default:
+		return rawKeySource{keys: config.SenderPrivateKeys}, nil
+	}
+}
+
+// rawKeySource signs with plaintext ECDSA private keys, the original key
+// source this tool supported.
+type rawKeySource struct {
+	keys []string
+}
+
+func (s rawKeySource) Accounts() ([]Account, error) {
+	accs := make([]Account, 0, len(s.keys))
+	for _, keyHex := range s.keys {
+		privateKey, err := crypto.HexToECDSA(keyHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %v", err)
+		}
+		accs = append(accs, Account{
+			Address: crypto.PubkeyToAddress(privateKey.PublicKey),
+			SignTx: func(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+				return types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
+			},
+		})
+	}
+	return accs, nil
+}
+
+// keystoreKeySource signs with accounts held in a go-ethereum V3 JSON
+// keystore directory, prompting for each account's passphrase once at
+// startup.
+type keystoreKeySource struct {
+	dir string
+}
+
+func (s keystoreKeySource) Accounts() ([]Account, error) {
+	ks := keystore.NewKeyStore(s.dir, keystore.StandardScryptN, keystore.StandardScryptP)
+
+	var accs []Account
+	for _, acct := range ks.Accounts() {
+		acct := acct
+		passphrase, err := prompt.Stdin.PromptPassword(fmt.Sprintf("Passphrase for %s: ", acct.Address.Hex()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase for %s: %v", acct.Address.Hex(), err)
+		}
+		accs = append(accs, Account{
+			Address: acct.Address,
+			SignTx: func(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+				return ks.SignTxWithPassphrase(acct, passphrase, tx, chainID)
+			},
+		})
+	}
+	return accs, nil
+}
+
+// mnemonicKeySource derives accounts from a BIP-39 mnemonic using the
+// standard Ethereum BIP-44 path m/44'/60'/0'/0/i for i in [0, count).
+type mnemonicKeySource struct {
+	file  string
+	count int
+}
+
+func (s mnemonicKeySource) Accounts() ([]Account, error) {
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mnemonic file: %v", err)
+	}
+	mnemonic := strings.TrimSpace(string(data))
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("invalid mnemonic in %s", s.file)
+	}
+
+	wallet, err := hdwallet.NewFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive wallet from mnemonic: %v", err)
+	}
+
+	count := s.count
+	if count <= 0 {
+		count = 1
+	}
+
+	accs := make([]Account, 0, count)
+	for i := 0; i < count; i++ {
+		path := hdwallet.MustParseDerivationPath(fmt.Sprintf("m/44'/60'/0'/0/%d", i))
+		derived, err := wallet.Derive(path, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive account %d: %v", i, err)
+		}
+		privateKey, err := wallet.PrivateKey(derived)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get private key for account %d: %v", i, err)
+		}
+		accs = append(accs, Account{
+			Address: derived.Address,
+			SignTx: func(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+				return types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
+			},
+		})
+	}
+	return accs, nil
+}
+
+// externalSignerKeySource delegates signing to a clef (or any
+// account_signTransaction-compatible) JSON-RPC endpoint, so the tool never
+// sees raw key material.
+type externalSignerKeySource struct {
+	endpoint string
+}
+
+func (s externalSignerKeySource) Accounts() ([]Account, error) {
+	signer, err := external.NewExternalSigner(s.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to external signer: %v", err)
+	}
+
+	var accs []Account
+	for _, acct := range signer.Accounts() {
+		acct := acct
+		accs = append(accs, Account{
+			Address: acct.Address,
+			SignTx: func(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+				return signer.SignTx(acct, tx, chainID)
+			},
+		})
+	}
+	return accs, nil
+}
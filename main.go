@@ -3,31 +3,99 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"math/big"
 	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type Config struct {
-	RPC               string   `json:"rpc"`
-	SenderPrivateKeys []string `json:"senderPrivateKeys"`
-	ReceiverAddress   string   `json:"receiverAddress"`
+	RPC               string        `json:"rpc"`
+	SenderPrivateKeys []string      `json:"senderPrivateKeys"`
+	ReceiverAddress   string        `json:"receiverAddress"`
+	GasTipCapWei      string        `json:"gasTipCapWei"`
+	Legacy            bool          `json:"legacy"`
+	Concurrency       int           `json:"concurrency"`
+	MaxRetries        int           `json:"maxRetries"`
+	RetryBackoff      string        `json:"retryBackoff"`
+	Tokens            []string      `json:"tokens"`
+	KeystoreDir       string        `json:"keystoreDir"`
+	MnemonicFile      string        `json:"mnemonicFile"`
+	DerivationCount   int           `json:"derivationCount"`
+	ExternalSigner    string        `json:"externalSigner"`
+	DryRun            bool          `json:"dryRun"`
+	MinSweepWei       string        `json:"minSweepWei"`
+	ExpectedChainID   int64         `json:"expectedChainId"`
+	Chains            []ChainConfig `json:"chains"`
+}
+
+// ChainConfig describes one of several chains to sweep the same set of
+// accounts across in a single invocation (e.g. Ethereum mainnet, Arbitrum,
+// Optimism, Polygon, BSC).
+type ChainConfig struct {
+	Name            string `json:"name"`
+	RPC             string `json:"rpc"`
+	ExpectedChainID int64  `json:"expectedChainId"`
+	Receiver        string `json:"receiver"`
 }
 
 var (
-	configFile     string
-	rpcFlag        string
-	receiverFlag   string
-	senderKeysFlag stringSliceFlag
+	configFile          string
+	rpcFlag             string
+	receiverFlag        string
+	senderKeysFlag      stringSliceFlag
+	gasTipCapFlag       string
+	legacyFlag          bool
+	concurrencyFlag     int
+	maxRetriesFlag      int
+	retryBackoffFlag    string
+	tokensFlag          stringSliceFlag
+	keystoreFlag        string
+	mnemonicFileFlag    string
+	derivationCountFlag int
+	externalSignerFlag  string
+	dryRunFlag          bool
+	minSweepWeiFlag     string
+	expectedChainIDFlag int64
 )
 
+// defaultRetryBackoff is the base delay used between retry attempts when
+// neither the config nor -retry-backoff specify one.
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// errBelowMinSweep signals that a sender's net sendable value fell below
+// config.MinSweepWei; sweepSender treats this as a skip, not a failure.
+var errBelowMinSweep = errors.New("value to send is below minimum sweep threshold")
+
+// retryBackoff returns the configured base backoff duration between retries,
+// falling back to defaultRetryBackoff when config.RetryBackoff is unset.
+func retryBackoff(config *Config) time.Duration {
+	if config.RetryBackoff == "" {
+		return defaultRetryBackoff
+	}
+	backoff, _ := time.ParseDuration(config.RetryBackoff)
+	return backoff
+}
+
+// minSweepThreshold parses config.MinSweepWei, defaulting to zero.
+func minSweepThreshold(config *Config) *big.Int {
+	if config.MinSweepWei == "" {
+		return big.NewInt(0)
+	}
+	threshold, _ := new(big.Int).SetString(config.MinSweepWei, 10)
+	return threshold
+}
+
 type stringSliceFlag []string
 
 func (s *stringSliceFlag) String() string {
@@ -44,6 +112,19 @@ func loadConfig() (*Config, error) {
 	flag.StringVar(&rpcFlag, "rpc", "", "EVM RPC URL")
 	flag.StringVar(&receiverFlag, "receiver", "", "Receiver address")
 	flag.Var(&senderKeysFlag, "sender", "Sender private key (can be specified multiple times)")
+	flag.StringVar(&gasTipCapFlag, "gas-tip-cap", "", "Manual maxPriorityFeePerGas override in wei (EIP-1559 chains only)")
+	flag.BoolVar(&legacyFlag, "legacy", false, "Force legacy (non EIP-1559) transactions even on London-enabled chains")
+	flag.IntVar(&concurrencyFlag, "concurrency", 0, "Number of senders to sweep in parallel (default 1)")
+	flag.IntVar(&maxRetriesFlag, "max-retries", 0, "Number of retry attempts per sender on transient RPC errors (default 3)")
+	flag.StringVar(&retryBackoffFlag, "retry-backoff", "", "Base backoff duration between retries, e.g. 500ms (default 500ms)")
+	flag.Var(&tokensFlag, "token", "ERC-20 token contract address to sweep before the native sweep (can be specified multiple times)")
+	flag.StringVar(&keystoreFlag, "keystore", "", "Path to a go-ethereum V3 JSON keystore directory (accounts are unlocked via passphrase prompt)")
+	flag.StringVar(&mnemonicFileFlag, "mnemonic-file", "", "Path to a file containing a BIP-39 mnemonic to derive sender accounts from")
+	flag.IntVar(&derivationCountFlag, "derivation-count", 0, "Number of BIP-44 accounts to derive from -mnemonic-file (default 1)")
+	flag.StringVar(&externalSignerFlag, "external-signer", "", "JSON-RPC endpoint of an external signer (e.g. clef) to sign with instead of local keys")
+	flag.BoolVar(&dryRunFlag, "dry-run", false, "Compute and sign transactions but do not broadcast them; print a preview table instead")
+	flag.StringVar(&minSweepWeiFlag, "min-sweep-wei", "", "Skip senders whose net sendable value falls below this threshold, in wei (default 0)")
+	flag.Int64Var(&expectedChainIDFlag, "expected-chain-id", 0, "Fail fast unless -rpc reports this chain ID (single-chain mode only)")
 	flag.Parse()
 
 	var config Config
@@ -70,19 +151,103 @@ func loadConfig() (*Config, error) {
 	if len(senderKeysFlag) > 0 {
 		config.SenderPrivateKeys = senderKeysFlag
 	}
+	if gasTipCapFlag != "" {
+		config.GasTipCapWei = gasTipCapFlag
+	}
+	if legacyFlag {
+		config.Legacy = true
+	}
+	if concurrencyFlag > 0 {
+		config.Concurrency = concurrencyFlag
+	}
+	if maxRetriesFlag > 0 {
+		config.MaxRetries = maxRetriesFlag
+	}
+	if retryBackoffFlag != "" {
+		config.RetryBackoff = retryBackoffFlag
+	}
+	if len(tokensFlag) > 0 {
+		config.Tokens = tokensFlag
+	}
+	if keystoreFlag != "" {
+		config.KeystoreDir = keystoreFlag
+	}
+	if mnemonicFileFlag != "" {
+		config.MnemonicFile = mnemonicFileFlag
+	}
+	if derivationCountFlag > 0 {
+		config.DerivationCount = derivationCountFlag
+	}
+	if externalSignerFlag != "" {
+		config.ExternalSigner = externalSignerFlag
+	}
+	if dryRunFlag {
+		config.DryRun = true
+	}
+	if minSweepWeiFlag != "" {
+		config.MinSweepWei = minSweepWeiFlag
+	}
+	if expectedChainIDFlag != 0 {
+		config.ExpectedChainID = expectedChainIDFlag
+	}
 
 	// Validate config
-	if config.RPC == "" {
-		return nil, fmt.Errorf("RPC URL is required")
+	if len(config.Chains) > 0 {
+		for i, chain := range config.Chains {
+			if chain.Name == "" {
+				return nil, fmt.Errorf("chains[%d]: name is required", i)
+			}
+			if chain.RPC == "" {
+				return nil, fmt.Errorf("chains[%d] (%s): rpc is required", i, chain.Name)
+			}
+			if chain.Receiver == "" {
+				return nil, fmt.Errorf("chains[%d] (%s): receiver is required", i, chain.Name)
+			}
+			if !common.IsHexAddress(chain.Receiver) {
+				return nil, fmt.Errorf("chains[%d] (%s): invalid receiver address", i, chain.Name)
+			}
+		}
+	} else {
+		if config.RPC == "" {
+			return nil, fmt.Errorf("RPC URL is required")
+		}
+		if config.ReceiverAddress == "" {
+			return nil, fmt.Errorf("receiver address is required")
+		}
+		if !common.IsHexAddress(config.ReceiverAddress) {
+			return nil, fmt.Errorf("invalid receiver address")
+		}
+	}
+	if len(config.SenderPrivateKeys) == 0 && config.KeystoreDir == "" && config.MnemonicFile == "" && config.ExternalSigner == "" {
+		return nil, fmt.Errorf("at least one key source is required (-sender, -keystore, -mnemonic-file, or -external-signer)")
+	}
+	if config.GasTipCapWei != "" {
+		if _, ok := new(big.Int).SetString(config.GasTipCapWei, 10); !ok {
+			return nil, fmt.Errorf("invalid gas tip cap: %s", config.GasTipCapWei)
+		}
+	}
+	if config.RetryBackoff != "" {
+		if _, err := time.ParseDuration(config.RetryBackoff); err != nil {
+			return nil, fmt.Errorf("invalid retry backoff: %v", err)
+		}
+	}
+	for _, token := range config.Tokens {
+		if !common.IsHexAddress(token) {
+			return nil, fmt.Errorf("invalid token address: %s", token)
+		}
 	}
-	if config.ReceiverAddress == "" {
-		return nil, fmt.Errorf("receiver address is required")
+	if config.MinSweepWei != "" {
+		if _, ok := new(big.Int).SetString(config.MinSweepWei, 10); !ok {
+			return nil, fmt.Errorf("invalid min sweep threshold: %s", config.MinSweepWei)
+		}
 	}
-	if !common.IsHexAddress(config.ReceiverAddress) {
-		return nil, fmt.Errorf("invalid receiver address")
+
+	// Apply defaults for the worker pool / retry knobs
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
 	}
-	if len(config.SenderPrivateKeys) == 0 {
-		return nil, fmt.Errorf("at least one sender private key is required")
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = 3
 	}
 
 	return &config, nil
@@ -94,84 +259,378 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	keySource, err := resolveKeySource(config)
+	if err != nil {
+		log.Fatalf("Failed to resolve key source: %v", err)
+	}
+	accounts, err := keySource.Accounts()
+	if err != nil {
+		log.Fatalf("Failed to load accounts: %v", err)
+	}
+	if len(accounts) == 0 {
+		log.Fatalf("Key source discovered no accounts to sweep")
+	}
+
+	if len(config.Chains) > 0 {
+		chainSummaries := sweepChains(config, accounts)
+
+		if config.DryRun {
+			for _, chainSummary := range chainSummaries {
+				fmt.Printf("Chain: %s\n", chainSummary.Name)
+				printDryRunTable(chainSummary.Summary.Results)
+			}
+		}
+
+		output, err := json.MarshalIndent(struct {
+			Chains []ChainSummary `json:"chains"`
+		}{chainSummaries}, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to marshal summary: %v", err)
+		}
+		fmt.Println(string(output))
+		return
+	}
+
 	// EVM client
 	client, err := ethclient.Dial(config.RPC)
 	if err != nil {
 		log.Fatalf("Failed to connect to the EVM client: %v", err)
 	}
 
+	if config.ExpectedChainID != 0 {
+		if err := chainPreflight(client, config.ExpectedChainID); err != nil {
+			log.Fatalf("Chain pre-flight check failed: %v", err)
+		}
+	}
+
 	// Receiver's address
 	receiverAddress := common.HexToAddress(config.ReceiverAddress)
 
-	for _, privateKeyHex := range config.SenderPrivateKeys {
-		// Transfer all ETH from each sender to the receiver
-		err := transferAllETH(client, privateKeyHex, receiverAddress)
-		if err != nil {
-			log.Printf("Failed to transfer ETH from private key %s: %v", privateKeyHex, err)
+	summary := sweepAll(client, config, accounts, receiverAddress)
+
+	if config.DryRun {
+		printDryRunTable(summary.Results)
+	}
+
+	output, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to marshal summary: %v", err)
+	}
+	fmt.Println(string(output))
+}
+
+// chainPreflight fails fast if the chain the client is actually connected to
+// does not match expectedChainID, so a misconfigured -rpc can't silently
+// sweep funds on the wrong network.
+func chainPreflight(client *ethclient.Client, expectedChainID int64) error {
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %v", err)
+	}
+	if chainID.Cmp(big.NewInt(expectedChainID)) != 0 {
+		return fmt.Errorf("connected to chain ID %s, expected %d", chainID, expectedChainID)
+	}
+	return nil
+}
+
+// printDryRunTable prints a preview table of the priced-but-unbroadcast
+// transactions so an operator can review a batch before sending -dry-run.
+func printDryRunTable(results []SweepResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "SENDER\tBALANCE\tGAS PRICE\tGAS COST\tVALUE TO SEND\tTX HASH\tSTATUS")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.SenderAddress, bigIntOrDash(r.BalanceWei), bigIntOrDash(r.GasPriceWei),
+			bigIntOrDash(r.GasCostWei), bigIntOrDash(r.ValueSentWei), r.TxHash, r.Status)
+	}
+	w.Flush()
+}
+
+func bigIntOrDash(v *big.Int) string {
+	if v == nil {
+		return "-"
+	}
+	return v.String()
+}
+
+// SweepResult is the per-sender outcome of a sweep attempt, reported in the
+// final JSON summary.
+type SweepResult struct {
+	SenderAddress  string   `json:"senderAddress"`
+	TokenTransfers []string `json:"tokenTransfers,omitempty"`
+	TxHash         string   `json:"txHash,omitempty"`
+	BalanceWei     *big.Int `json:"balanceWei,omitempty"`
+	GasPriceWei    *big.Int `json:"gasPriceWei,omitempty"`
+	GasCostWei     *big.Int `json:"gasCostWei,omitempty"`
+	ValueSentWei   *big.Int `json:"valueSentWei,omitempty"`
+	GasUsed        uint64   `json:"gasUsed,omitempty"`
+	GasPaidWei     *big.Int `json:"gasPaidWei,omitempty"`
+	Status         string   `json:"status"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// SweepSummary aggregates the results of sweeping every configured sender.
+type SweepSummary struct {
+	Successes        int           `json:"successes"`
+	Failures         int           `json:"failures"`
+	TotalETHSweptWei *big.Int      `json:"totalEthSweptWei"`
+	TotalGasPaidWei  *big.Int      `json:"totalGasPaidWei"`
+	Results          []SweepResult `json:"results"`
+}
+
+// sweepAll runs sweepSender for every account discovered by the
+// configured KeySource, using a worker pool bounded by config.Concurrency,
+// waits for each transaction to be mined, and aggregates the outcomes into
+// a SweepSummary.
+func sweepAll(client *ethclient.Client, config *Config, accounts []Account, receiverAddress common.Address) SweepSummary {
+	jobs := make(chan Account)
+	resultsCh := make(chan SweepResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for account := range jobs {
+				resultsCh <- sweepSender(client, config, account, receiverAddress)
+			}
+		}()
+	}
+
+	go func() {
+		for _, account := range accounts {
+			jobs <- account
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	summary := SweepSummary{
+		TotalETHSweptWei: big.NewInt(0),
+		TotalGasPaidWei:  big.NewInt(0),
+	}
+	for result := range resultsCh {
+		summary.Results = append(summary.Results, result)
+		switch result.Status {
+		case "success":
+			summary.Successes++
+			summary.TotalETHSweptWei.Add(summary.TotalETHSweptWei, result.ValueSentWei)
+			summary.TotalGasPaidWei.Add(summary.TotalGasPaidWei, result.GasPaidWei)
+		case "failed":
+			summary.Failures++
 		}
 	}
+	return summary
 }
 
-func transferAllETH(client *ethclient.Client, privateKeyHex string, receiverAddress common.Address) error {
-	// Convert the private key from hex to ECDSA format
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+// sweepSender sends and confirms a single sweep transaction, retrying
+// transient RPC errors with exponential backoff per config.MaxRetries /
+// config.RetryBackoff.
+func sweepSender(client *ethclient.Client, config *Config, account Account, receiverAddress common.Address) SweepResult {
+	result := SweepResult{Status: "failed", SenderAddress: account.Address.Hex()}
+
+	backoff := retryBackoff(config)
+
+	// sweepERC20Tokens broadcasts and waits for each token transfer itself;
+	// it is not retried as a whole here, since a merely-slow WaitMined on an
+	// already-broadcast transfer would otherwise cause a second, duplicate
+	// transfer of the same balance. Its own idempotent reads retry internally.
+	tokenHashes, tokenErr := sweepERC20Tokens(client, config, account, receiverAddress)
+	result.TokenTransfers = append(result.TokenTransfers, tokenHashes...)
+	if tokenErr != nil {
+		result.Error = tokenErr.Error()
+		return result
+	}
+
+	var signedTx *types.Transaction
+	var pricing txPricing
+	err := withRetry(config.MaxRetries, backoff, func() error {
+		tx, p, txErr := priceAndSignSweepTx(client, config, account, receiverAddress)
+		signedTx, pricing = tx, p
+		return txErr
+	})
+	result.BalanceWei = pricing.BalanceWei
+	result.GasPriceWei = pricing.GasPriceWei
+	result.GasCostWei = pricing.GasCostWei
+	result.ValueSentWei = pricing.ValueToSend
+	if err != nil {
+		if errors.Is(err, errBelowMinSweep) {
+			result.Status = "skipped"
+			return result
+		}
+		result.Error = err.Error()
+		return result
+	}
+	result.TxHash = signedTx.Hash().Hex()
+
+	if config.DryRun {
+		result.Status = "dry-run"
+		return result
+	}
+
+	// SendTransaction is sent once, not retried: on an ambiguous error (RPC
+	// timeout, "already known") the transaction may already be in the
+	// mempool, and retrying would re-price and rebroadcast a second
+	// full-balance sweep at the next nonce.
+	if err := client.SendTransaction(context.Background(), signedTx); err != nil {
+		result.Error = fmt.Sprintf("failed to send transaction: %v", err)
+		return result
+	}
+	log.Printf("Transaction sent: %s", signedTx.Hash().Hex())
+
+	receipt, err := bind.WaitMined(context.Background(), client, signedTx)
 	if err != nil {
-		return fmt.Errorf("invalid private key: %v", err)
+		result.Error = fmt.Sprintf("transaction sent but not confirmed: %v", err)
+		return result
+	}
+	result.GasUsed = receipt.GasUsed
+	// EffectiveGasPrice is the price actually paid; for a DynamicFeeTx,
+	// signedTx.GasPrice() would instead return GasFeeCap, overstating cost.
+	result.GasPaidWei = new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		result.Status = "success"
+	} else {
+		result.Error = "transaction reverted"
+	}
+	return result
+}
+
+// withRetry calls fn until it succeeds or maxRetries additional attempts have
+// been exhausted, sleeping with exponential backoff between attempts.
+func withRetry(maxRetries int, backoff time.Duration, fn func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || errors.Is(err, errBelowMinSweep) || attempt >= maxRetries {
+			return err
+		}
+		time.Sleep(backoff * time.Duration(1<<attempt))
 	}
+}
 
-	// Derive the sender's address from the private key
-	senderAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
-	fmt.Println("Sender Address:", senderAddress.Hex())
+// txPricing captures the fee/value figures behind a sweep transaction, so
+// dry-run mode can report them without broadcasting.
+type txPricing struct {
+	BalanceWei  *big.Int
+	GasPriceWei *big.Int
+	GasCostWei  *big.Int
+	ValueToSend *big.Int
+}
+
+// priceAndSignSweepTx prices and signs (but does not broadcast) a transaction
+// that sweeps account's entire balance to receiverAddress. It is idempotent
+// and safe to retry; the caller is responsible for broadcasting the signed
+// transaction exactly once.
+func priceAndSignSweepTx(client *ethclient.Client, config *Config, account Account, receiverAddress common.Address) (*types.Transaction, txPricing, error) {
+	senderAddress := account.Address
+	log.Printf("Sender Address: %s", senderAddress.Hex())
 
 	// Get the sender's nonce (transaction count)
 	nonce, err := client.PendingNonceAt(context.Background(), senderAddress)
 	if err != nil {
-		return fmt.Errorf("failed to get nonce: %v", err)
+		return nil, txPricing{}, fmt.Errorf("failed to get nonce: %v", err)
 	}
 
 	// Get the current balance of the sender
 	balance, err := client.BalanceAt(context.Background(), senderAddress, nil)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve account balance: %v", err)
+		return nil, txPricing{}, fmt.Errorf("failed to retrieve account balance: %v", err)
 	}
-	fmt.Println("Sender Balance:", balance)
+	log.Printf("Sender Balance: %s", balance)
 
-	// Get the gas price
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	// Define the gas limit for a standard ETH transfer
+	gasLimit := uint64(21000)
+
+	chainID, err := client.ChainID(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to get gas price: %v", err)
+		return nil, txPricing{}, fmt.Errorf("failed to get chain ID: %v", err)
 	}
 
-	// Define the gas limit for a standard ETH transfer
-	gasLimit := uint64(21000)
+	// Detect London-enabled chains (non-nil BaseFee) unless legacy mode is forced
+	var header *types.Header
+	if !config.Legacy {
+		header, err = client.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return nil, txPricing{}, fmt.Errorf("failed to get latest header: %v", err)
+		}
+	}
+
+	var signedTx *types.Transaction
+	var pricing txPricing
+	if header != nil && header.BaseFee != nil {
+		signedTx, pricing, err = buildDynamicFeeTx(client, config, account, header.BaseFee, chainID, nonce, receiverAddress, balance, gasLimit)
+		if err != nil {
+			return nil, pricing, err
+		}
+	} else {
+		// Get the gas price
+		gasPrice, err := client.SuggestGasPrice(context.Background())
+		if err != nil {
+			return nil, txPricing{}, fmt.Errorf("failed to get gas price: %v", err)
+		}
 
-	// Calculate the value to send (balance - gasCost)
-	gasCost := new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))
-	if balance.Cmp(gasCost) <= 0 {
-		return fmt.Errorf("insufficient balance to cover gas cost")
+		// Calculate the value to send (balance - gasCost)
+		gasCost := new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))
+		valueToSend := new(big.Int).Sub(balance, gasCost)
+		pricing = txPricing{BalanceWei: balance, GasPriceWei: gasPrice, GasCostWei: gasCost, ValueToSend: valueToSend}
+		if valueToSend.Sign() <= 0 || valueToSend.Cmp(minSweepThreshold(config)) < 0 {
+			return nil, pricing, errBelowMinSweep
+		}
+
+		// Create the transaction
+		tx := types.NewTransaction(nonce, receiverAddress, valueToSend, gasLimit, gasPrice, nil)
+
+		// Sign the transaction with the sender's account
+		signedTx, err = account.SignTx(tx, chainID)
+		if err != nil {
+			return nil, pricing, fmt.Errorf("failed to sign transaction: %v", err)
+		}
 	}
-	valueToSend := new(big.Int).Sub(balance, gasCost)
 
-	// Create the transaction
-	tx := types.NewTransaction(nonce, receiverAddress, valueToSend, gasLimit, gasPrice, nil)
+	return signedTx, pricing, nil
+}
 
-	// Sign the transaction with the sender's private key
-	chainID, err := client.NetworkID(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to get network ID: %v", err)
+// buildDynamicFeeTx constructs and signs an EIP-1559 transaction that sweeps the
+// sender's entire balance, net of maxFeePerGas*gasLimit, to receiverAddress.
+func buildDynamicFeeTx(client *ethclient.Client, config *Config, account Account, baseFee *big.Int, chainID *big.Int, nonce uint64, receiverAddress common.Address, balance *big.Int, gasLimit uint64) (*types.Transaction, txPricing, error) {
+	var tipCap *big.Int
+	if config.GasTipCapWei != "" {
+		tipCap, _ = new(big.Int).SetString(config.GasTipCapWei, 10)
+	} else {
+		suggestedTip, err := client.SuggestGasTipCap(context.Background())
+		if err != nil {
+			return nil, txPricing{}, fmt.Errorf("failed to get suggested gas tip cap: %v", err)
+		}
+		tipCap = suggestedTip
 	}
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
-	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %v", err)
+	// maxFeePerGas = baseFee*2 + tip, giving headroom for a few blocks of base fee increase
+	maxFeePerGas := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tipCap)
+
+	gasCost := new(big.Int).Mul(maxFeePerGas, big.NewInt(int64(gasLimit)))
+	valueToSend := new(big.Int).Sub(balance, gasCost)
+	pricing := txPricing{BalanceWei: balance, GasPriceWei: maxFeePerGas, GasCostWei: gasCost, ValueToSend: valueToSend}
+	if valueToSend.Sign() <= 0 || valueToSend.Cmp(minSweepThreshold(config)) < 0 {
+		return nil, pricing, errBelowMinSweep
 	}
 
-	// Send the transaction
-	err = client.SendTransaction(context.Background(), signedTx)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: maxFeePerGas,
+		Gas:       gasLimit,
+		To:        &receiverAddress,
+		Value:     valueToSend,
+	})
+
+	signedTx, err := account.SignTx(tx, chainID)
 	if err != nil {
-		return fmt.Errorf("failed to send transaction: %v", err)
+		return nil, pricing, fmt.Errorf("failed to sign transaction: %v", err)
 	}
-
-	fmt.Printf("Transaction sent: %s\n", signedTx.Hash().Hex())
-	return nil
+	return signedTx, pricing, nil
 }